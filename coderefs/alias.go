@@ -4,61 +4,200 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/google/shlex"
 	"github.com/iancoleman/strcase"
 	"github.com/launchdarkly/ld-find-code-refs/internal/helpers"
 	"github.com/launchdarkly/ld-find-code-refs/internal/validation"
 	"github.com/launchdarkly/ld-find-code-refs/options"
 
 	"github.com/bmatcuk/doublestar/v4"
+	gitignore "github.com/sabhiram/go-gitignore"
 )
 
 // GenerateAliases returns a map of flag keys to aliases based on config.
 func GenerateAliases(flags []string, aliases []options.Alias, dir string) (map[string][]string, error) {
-	allFileContents, err := processFileContent(aliases, dir)
-	if err != nil {
+	if err := validation.ValidateAliases(aliases); err != nil {
 		return nil, err
 	}
 
 	ret := make(map[string][]string, len(flags))
+
+	filePatternMatches, err := scanFilePatternAliases(flags, aliases, dir)
+	if err != nil {
+		return nil, err
+	}
+	for flag, matches := range filePatternMatches {
+		ret[flag] = append(ret[flag], matches...)
+	}
+
+	symbolMatches, err := scanSymbolAliases(flags, aliases, dir)
+	if err != nil {
+		return nil, err
+	}
+	for flag, matches := range symbolMatches {
+		ret[flag] = append(ret[flag], matches...)
+	}
+
 	for _, flag := range flags {
 		for _, a := range aliases {
-			flagAliases, err := generateAlias(a, flag, dir, allFileContents)
+			if a.Type.Canonical() == options.FilePattern || a.Type.Canonical() == options.Symbol {
+				continue
+			}
+			flagAliases, err := generateAlias(a, flag, dir)
 			if err != nil {
 				return nil, err
 			}
 			ret[flag] = append(ret[flag], flagAliases...)
 		}
+	}
+
+	for _, flag := range flags {
 		ret[flag] = helpers.Dedupe(ret[flag])
 	}
 	return ret, nil
 }
 
-func globToAbsolutePaths(basepath string, pattern string) ([]string, error) {
+// globToAbsolutePaths expands patterns into absolute paths rooted at basepath.
+// Patterns are applied in order; a pattern prefixed with "!" is a negation that
+// subtracts its matches from whatever has been matched by earlier patterns so
+// far, mirroring how .gitignore-style include/exclude lists compose. When
+// respectGitignore is set, any path matched by a .gitignore found along the
+// walk under basepath is filtered out of the result, along with the .gitignore
+// files themselves.
+func globToAbsolutePaths(basepath string, patterns []string, respectGitignore bool) ([]string, error) {
 	fsys := os.DirFS(basepath)
 
-	matches, err := doublestar.Glob(fsys, pattern)
-	if err != nil {
-		return nil, fmt.Errorf("could not process path glob '%s'", filepath.Join(basepath, pattern))
+	matched := []string{}
+	matchedSet := map[string]bool{}
+
+	for _, pattern := range patterns {
+		negate := strings.HasPrefix(pattern, "!")
+		glob := strings.TrimPrefix(pattern, "!")
+
+		matches, err := doublestar.Glob(fsys, glob)
+		if err != nil {
+			return nil, fmt.Errorf("could not process path glob '%s'", filepath.Join(basepath, glob))
+		}
+
+		if negate {
+			toRemove := make(map[string]bool, len(matches))
+			for _, match := range matches {
+				toRemove[match] = true
+			}
+			filtered := matched[:0]
+			for _, match := range matched {
+				if toRemove[match] {
+					delete(matchedSet, match)
+					continue
+				}
+				filtered = append(filtered, match)
+			}
+			matched = filtered
+			continue
+		}
+
+		for _, match := range matches {
+			if !matchedSet[match] {
+				matchedSet[match] = true
+				matched = append(matched, match)
+			}
+		}
 	}
 
-	updatedMatches := matches[:0]
-	for _, match := range matches {
-		updatedMatches = append(updatedMatches, strings.Join([]string{basepath, match}, "/"))
+	if respectGitignore {
+		ignore, err := loadGitignore(basepath)
+		if err != nil {
+			return nil, err
+		}
+		filtered := matched[:0]
+		for _, match := range matched {
+			if filepath.Base(match) == ".gitignore" {
+				continue
+			}
+			if ignore != nil && ignore.MatchesPath(match) {
+				continue
+			}
+			filtered = append(filtered, match)
+		}
+		matched = filtered
+	}
+
+	ret := make([]string, 0, len(matched))
+	for _, match := range matched {
+		ret = append(ret, strings.Join([]string{basepath, match}, "/"))
+	}
+
+	return ret, nil
+}
+
+// loadGitignore compiles the combined ignore rules of every .gitignore found
+// under basepath, so gitignore-based filtering works regardless of which
+// subdirectory a rule was defined in. Returns a nil matcher if no .gitignore
+// files are present.
+func loadGitignore(basepath string) (*gitignore.GitIgnore, error) {
+	var lines []string
+
+	err := filepath.WalkDir(basepath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || d.Name() != ".gitignore" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(basepath, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+
+		/* #nosec */
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			if rel != "." {
+				if strings.HasPrefix(line, "!") {
+					line = "!" + filepath.ToSlash(filepath.Join(rel, strings.TrimPrefix(line[1:], "/")))
+				} else {
+					line = filepath.ToSlash(filepath.Join(rel, strings.TrimPrefix(line, "/")))
+				}
+			}
+			lines = append(lines, line)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not read .gitignore files under '%s': %w", basepath, err)
+	}
+	if len(lines) == 0 {
+		return nil, nil
 	}
 
-	return updatedMatches, nil
+	return gitignore.CompileIgnoreLines(lines...), nil
 }
 
-func generateAlias(a options.Alias, flag, dir string, allFileContents map[string][]byte) ([]string, error) {
+func generateAlias(a options.Alias, flag, dir string) ([]string, error) {
 	ret := []string{}
 	switch a.Type.Canonical() {
 	case options.Literal:
@@ -75,31 +214,6 @@ func generateAlias(a options.Alias, flag, dir string, allFileContents map[string
 		ret = []string{strcase.ToKebab(flag)}
 	case options.DotCase:
 		ret = []string{strcase.ToDelimited(flag, '.')}
-	case options.FilePattern:
-		// Concatenate the contents of all files into a single byte array to be matched by specified patterns
-		fileContents := []byte{}
-		for _, path := range a.Paths {
-			matches, err := globToAbsolutePaths(dir, path)
-			if err != nil {
-				return nil, fmt.Errorf("could not process path glob '%s'", filepath.Join(dir, path))
-			}
-			for _, match := range matches {
-				pathFileContents := allFileContents[match]
-				if len(pathFileContents) > 0 {
-					fileContents = append(fileContents, pathFileContents...)
-				}
-			}
-		}
-
-		for _, p := range a.Patterns {
-			pattern := regexp.MustCompile(strings.ReplaceAll(p, "FLAG_KEY", flag))
-			results := pattern.FindAllStringSubmatch(string(fileContents), -1)
-			for _, res := range results {
-				if len(res) > 1 {
-					ret = append(ret, res[1:]...)
-				}
-			}
-		}
 	case options.Command:
 		ctx := context.Background()
 		if a.Timeout != nil && *a.Timeout > 0 {
@@ -107,7 +221,13 @@ func generateAlias(a options.Alias, flag, dir string, allFileContents map[string
 			ctx, cancel = context.WithDeadline(ctx, time.Now().Add(time.Second*time.Duration(*a.Timeout)))
 			defer cancel()
 		}
-		tokens := strings.Split(*a.Command, " ")
+		tokens, err := shlex.Split(*a.Command)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse alias command '%s': %w", *a.Command, err)
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("alias command '%s' did not resolve to any tokens", *a.Command)
+		}
 		name := tokens[0]
 		args := []string{}
 		if len(tokens) > 1 {
@@ -130,9 +250,21 @@ func generateAlias(a options.Alias, flag, dir string, allFileContents map[string
 	return ret, nil
 }
 
-// processFileContent reads and stores the content of files specified by filePattern alias matchers to be matched for aliases
-func processFileContent(aliases []options.Alias, dir string) (map[string][]byte, error) {
-	allFileContents := map[string][]byte{}
+// compiledFilePatternMatcher is a single FLAG_KEY-substituted pattern, precompiled
+// once per (alias, flag) pair rather than once per scanned file.
+type compiledFilePatternMatcher struct {
+	flag  string
+	regex *regexp.Regexp
+}
+
+// scanFilePatternAliases resolves every FilePattern alias's matched files and scans
+// each file exactly once, running all of that alias's per-flag compiled patterns
+// against the file's buffer. File contents are never retained beyond the worker
+// that read them. Scanning is parallelized across a bounded worker pool, so peak
+// memory no longer scales with total repo bytes.
+func scanFilePatternAliases(flags []string, aliases []options.Alias, dir string) (map[string][]string, error) {
+	ret := map[string][]string{}
+
 	for idx, a := range aliases {
 		if a.Type.Canonical() != options.FilePattern {
 			continue
@@ -143,33 +275,169 @@ func processFileContent(aliases []options.Alias, dir string) (map[string][]byte,
 			aliasId = a.Name
 		}
 
-		paths := []string{}
-		for _, glob := range a.Paths {
-			matches, err := globToAbsolutePaths(dir, glob)
-			if err != nil {
-				return nil, fmt.Errorf("filepattern '%s': could not process path glob '%s'", aliasId, filepath.Join(dir, glob))
+		paths, err := matchFilePatternPaths(a, dir, aliasId)
+		if err != nil {
+			return nil, err
+		}
+
+		matchers := make([]compiledFilePatternMatcher, 0, len(flags)*len(a.Patterns))
+		for _, flag := range flags {
+			for _, p := range a.Patterns {
+				matchers = append(matchers, compiledFilePatternMatcher{
+					flag:  flag,
+					regex: regexp.MustCompile(strings.ReplaceAll(p, "FLAG_KEY", flag)),
+				})
 			}
+		}
 
-			paths = append(paths, matches...)
+		matches, err := scanFiles(paths, matchers, a.Workers)
+		if err != nil {
+			return nil, fmt.Errorf("filepattern '%s': %w", aliasId, err)
+		}
+		for flag, found := range matches {
+			ret[flag] = append(ret[flag], found...)
 		}
-		paths = helpers.Dedupe(paths)
+	}
 
-		for _, path := range paths {
-			_, pathAlreadyProcessed := allFileContents[path]
-			if pathAlreadyProcessed {
+	return ret, nil
+}
+
+// matchFilePatternPaths expands a FilePattern alias's Paths globs, subtracts its
+// Excludes globs, and filters out files blacklisted by extension or over MaxFileSize.
+func matchFilePatternPaths(a options.Alias, dir, aliasId string) ([]string, error) {
+	paths, err := globToAbsolutePaths(dir, a.Paths, a.RespectGitignore)
+	if err != nil {
+		return nil, fmt.Errorf("filepattern '%s': could not process paths: %w", aliasId, err)
+	}
+
+	excludeMatches, err := globToAbsolutePaths(dir, a.Excludes, false)
+	if err != nil {
+		return nil, fmt.Errorf("filepattern '%s': could not process excludes: %w", aliasId, err)
+	}
+	excludes := make(map[string]bool, len(excludeMatches))
+	for _, match := range excludeMatches {
+		excludes[match] = true
+	}
+
+	paths = helpers.Dedupe(paths)
+
+	ret := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if excludes[path] || hasExcludedExtension(path, a.ExcludeExtensions) {
+			continue
+		}
+
+		if !validation.FileExists(path) {
+			return nil, fmt.Errorf("filepattern '%s': could not find file at path '%s'", aliasId, path)
+		}
+
+		if a.MaxFileSize > 0 {
+			info, err := os.Stat(path)
+			if err != nil {
+				return nil, fmt.Errorf("filepattern '%s': could not stat file at path '%s': %v", aliasId, path, err)
+			}
+			if info.Size() > a.MaxFileSize {
 				continue
 			}
+		}
+
+		ret = append(ret, path)
+	}
+	return ret, nil
+}
+
+// hasExcludedExtension returns true if path ends with any of the given extensions.
+func hasExcludedExtension(path string, excludeExtensions []string) bool {
+	for _, ext := range excludeExtensions {
+		if strings.HasSuffix(path, ext) {
+			return true
+		}
+	}
+	return false
+}
 
-			if !validation.FileExists(path) {
-				return nil, fmt.Errorf("filepattern '%s': could not find file at path '%s'", aliasId, path)
+// fileScanResult carries one file's matches, keyed by flag, back to the merging goroutine.
+type fileScanResult struct {
+	matches map[string][]string
+	err     error
+}
+
+// scanFiles reads each path exactly once across a bounded pool of workers (size
+// workers, defaulting to runtime.NumCPU() when <= 0) and applies every matcher to
+// that file's buffer, merging the per-flag results of all files.
+func scanFiles(paths []string, matchers []compiledFilePatternMatcher, workers int) (map[string][]string, error) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(paths) {
+		workers = len(paths)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan string)
+	results := make(chan fileScanResult)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for path := range jobs {
+				results <- scanFile(path, matchers)
 			}
-			/* #nosec */
-			data, err := ioutil.ReadFile(path)
-			if err != nil {
-				return nil, fmt.Errorf("filepattern '%s': could not process file at path '%s': %v", aliasId, path, err)
+		}()
+	}
+
+	go func() {
+		for _, path := range paths {
+			jobs <- path
+		}
+		close(jobs)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	ret := map[string][]string{}
+	var firstErr error
+	for res := range results {
+		if res.err != nil {
+			if firstErr == nil {
+				firstErr = res.err
+			}
+			continue
+		}
+		for flag, found := range res.matches {
+			ret[flag] = append(ret[flag], found...)
+		}
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return ret, nil
+}
+
+// scanFile reads path once and applies every matcher to its contents.
+func scanFile(path string, matchers []compiledFilePatternMatcher) fileScanResult {
+	/* #nosec */
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fileScanResult{err: fmt.Errorf("could not process file at path '%s': %w", path, err)}
+	}
+
+	contents := string(data)
+	matches := map[string][]string{}
+	for _, m := range matchers {
+		for _, res := range m.regex.FindAllStringSubmatch(contents, -1) {
+			if len(res) > 1 {
+				matches[m.flag] = append(matches[m.flag], res[1:]...)
 			}
-			allFileContents[path] = data
 		}
 	}
-	return allFileContents, nil
+	return fileScanResult{matches: matches}
 }
@@ -0,0 +1,42 @@
+package coderefs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGlobToAbsolutePaths_NestedGitignoreNegation ensures that a negation rule
+// declared in a nested .gitignore (e.g. "sub/.gitignore" containing "!keep.log")
+// is rewritten relative to its own directory and still parses as a negation,
+// rather than being rewritten into a literal pattern with a stray leading "!".
+func TestGlobToAbsolutePaths_NestedGitignoreNegation(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	require.NoError(t, ioutil.WriteFile(
+		filepath.Join(dir, "sub", ".gitignore"),
+		[]byte("*.log\n!keep.log\n"),
+		0644,
+	))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "sub", "app.log"), []byte("x"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "sub", "keep.log"), []byte("x"), 0644))
+
+	matches, err := globToAbsolutePaths(dir, []string{"sub/*"}, true)
+	require.NoError(t, err)
+
+	rel := make([]string, 0, len(matches))
+	for _, m := range matches {
+		r, err := filepath.Rel(dir, m)
+		require.NoError(t, err)
+		rel = append(rel, filepath.ToSlash(r))
+	}
+	sort.Strings(rel)
+
+	assert.Equal(t, []string{"sub/keep.log"}, rel)
+}
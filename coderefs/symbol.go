@@ -0,0 +1,174 @@
+package coderefs
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/launchdarkly/ld-find-code-refs/options"
+)
+
+// defaultSimilarityThreshold is used by a Symbol alias that doesn't configure one.
+const defaultSimilarityThreshold = 1.0
+
+// symbolCandidate is a declared identifier found while tokenizing a single file,
+// along with the string literal value it was assigned (if any). Matching against
+// a flag key happens once per flag against the candidates already extracted from
+// every file, rather than by re-tokenizing the file per flag.
+type symbolCandidate struct {
+	name  string
+	value string
+}
+
+// symbolTokenizer extracts every candidate declared identifier from a single
+// source file. It returns a file-scoped error (e.g. a syntax error) rather than
+// aborting the caller, so one unparseable file doesn't take down the whole alias.
+type symbolTokenizer func(path string) ([]symbolCandidate, error)
+
+// symbolTokenizers maps a file extension to the language-aware tokenizer used to
+// extract candidate symbols from it. Additional languages register here.
+var symbolTokenizers = map[string]symbolTokenizer{
+	".go": tokenizeGoSymbols,
+}
+
+// languageExtensions maps a Symbol alias's configured Languages entries to the
+// file extension their tokenizer is registered under.
+var languageExtensions = map[string]string{
+	"go": ".go",
+}
+
+// scanSymbolAliases resolves every Symbol alias's matched files and tokenizes each
+// file exactly once per alias, then matches the resulting candidates against every
+// flag in a single pass. This avoids re-globbing and re-parsing the same files once
+// per flag, mirroring how scanFilePatternAliases scans each file exactly once.
+func scanSymbolAliases(flags []string, aliases []options.Alias, dir string) (map[string][]string, error) {
+	ret := map[string][]string{}
+
+	for idx, a := range aliases {
+		if a.Type.Canonical() != options.Symbol {
+			continue
+		}
+
+		aliasId := strconv.Itoa(idx)
+		if a.Name != "" {
+			aliasId = a.Name
+		}
+
+		paths, err := matchFilePatternPaths(a, dir, aliasId)
+		if err != nil {
+			return nil, err
+		}
+
+		threshold := a.SimilarityThreshold
+		if threshold <= 0 {
+			threshold = defaultSimilarityThreshold
+		}
+
+		candidates := []symbolCandidate{}
+		for _, path := range paths {
+			ext := filepath.Ext(path)
+			if len(a.Languages) > 0 && !extensionInLanguages(ext, a.Languages) {
+				continue
+			}
+
+			tokenizer, ok := symbolTokenizers[ext]
+			if !ok {
+				continue
+			}
+
+			found, err := tokenizer(path)
+			if err != nil {
+				// A single unparseable file (a generated stub, a template accidentally
+				// matched by a broad glob, unsupported syntax) shouldn't take down
+				// alias resolution for every flag; skip just this file.
+				continue
+			}
+			candidates = append(candidates, found...)
+		}
+
+		for _, flag := range flags {
+			var matches []string
+			for _, c := range candidates {
+				if isCaseVariant(c.name, flag) {
+					matches = append(matches, c.name)
+					continue
+				}
+				if c.value != "" && symbolSimilarity(c.value, flag) >= threshold {
+					matches = append(matches, c.name)
+				}
+			}
+			ret[flag] = append(ret[flag], matches...)
+		}
+	}
+
+	return ret, nil
+}
+
+func extensionInLanguages(ext string, languages []string) bool {
+	for _, lang := range languages {
+		if languageExtensions[strings.ToLower(lang)] == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenizeGoSymbols parses a Go source file and returns every declared constant
+// and variable as a symbolCandidate, pairing its identifier name with the string
+// literal value it was assigned, if any.
+func tokenizeGoSymbols(path string) ([]symbolCandidate, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse file at path '%s': %w", path, err)
+	}
+
+	ret := []symbolCandidate{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		decl, ok := n.(*ast.GenDecl)
+		if !ok || (decl.Tok != token.CONST && decl.Tok != token.VAR) {
+			return true
+		}
+
+		for _, spec := range decl.Specs {
+			valueSpec, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			for i, name := range valueSpec.Names {
+				candidate := symbolCandidate{name: name.Name}
+				if i < len(valueSpec.Values) {
+					if lit, ok := valueSpec.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						if value, err := strconv.Unquote(lit.Value); err == nil {
+							candidate.value = value
+						}
+					}
+				}
+				ret = append(ret, candidate)
+			}
+		}
+		return true
+	})
+	return ret, nil
+}
+
+// isCaseVariant returns true if name and flag normalize to the same snake_case
+// form, i.e. name is some case-convention rewrite of flag's words.
+func isCaseVariant(name, flag string) bool {
+	return strcase.ToSnake(name) == strcase.ToSnake(flag)
+}
+
+// symbolSimilarity scores how similar a is to b, from 0 (unrelated) to 1
+// (identical). Only exact matches score 1 today; this is the hook future
+// tokenizers use to report partial matches against a lower threshold.
+func symbolSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	return 0
+}
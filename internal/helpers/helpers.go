@@ -0,0 +1,16 @@
+package helpers
+
+// Dedupe returns a copy of items with duplicate entries removed, preserving
+// the order in which each distinct value first appeared.
+func Dedupe(items []string) []string {
+	seen := make(map[string]struct{}, len(items))
+	ret := make([]string, 0, len(items))
+	for _, item := range items {
+		if _, ok := seen[item]; ok {
+			continue
+		}
+		seen[item] = struct{}{}
+		ret = append(ret, item)
+	}
+	return ret
+}
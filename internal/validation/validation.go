@@ -0,0 +1,37 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/google/shlex"
+	"github.com/launchdarkly/ld-find-code-refs/options"
+)
+
+// FileExists returns true if a regular file exists at path.
+func FileExists(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return !info.IsDir()
+}
+
+// ValidateAliases checks that each configured alias is well-formed, returning
+// an error describing the first problem found. Command aliases are validated
+// by shell-tokenizing the configured command up front so that malformed
+// quoting is reported before any alias is generated rather than at exec time.
+func ValidateAliases(aliases []options.Alias) error {
+	for _, a := range aliases {
+		if a.Type.Canonical() != options.Command {
+			continue
+		}
+		if a.Command == nil {
+			return fmt.Errorf("alias of type '%s' must configure a command", options.Command)
+		}
+		if _, err := shlex.Split(*a.Command); err != nil {
+			return fmt.Errorf("could not parse alias command '%s': %w", *a.Command, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/launchdarkly/ld-find-code-refs/options"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAliases_CommandWithNilCommand(t *testing.T) {
+	err := ValidateAliases([]options.Alias{{Type: options.Command, Command: nil}})
+	assert.Error(t, err)
+}
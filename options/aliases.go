@@ -0,0 +1,64 @@
+package options
+
+import "strings"
+
+// AliasType represents the strategy used to derive additional aliases for a flag key.
+type AliasType string
+
+// Canonical AliasType values. Alias configuration is matched case-insensitively
+// against these via AliasType.Canonical().
+const (
+	Literal        AliasType = "literal"
+	CamelCase      AliasType = "camelcase"
+	PascalCase     AliasType = "pascalcase"
+	SnakeCase      AliasType = "snakecase"
+	UpperSnakeCase AliasType = "uppersnakecase"
+	KebabCase      AliasType = "kebabcase"
+	DotCase        AliasType = "dotcase"
+	FilePattern    AliasType = "filepattern"
+	Command        AliasType = "command"
+	Symbol         AliasType = "symbol"
+)
+
+// Canonical normalizes the casing of a configured AliasType so that it can be
+// compared against the constants above regardless of how the user wrote it.
+func (a AliasType) Canonical() AliasType {
+	return AliasType(strings.ToLower(string(a)))
+}
+
+// Alias defines a single alias-generation strategy configured by the user.
+type Alias struct {
+	Type     AliasType           `json:"type" yaml:"type"`
+	Name     string              `json:"name,omitempty" yaml:"name,omitempty"`
+	Flags    map[string][]string `json:"flags,omitempty" yaml:"flags,omitempty"`
+	Paths    []string            `json:"paths,omitempty" yaml:"paths,omitempty"`
+	Patterns []string            `json:"patterns,omitempty" yaml:"patterns,omitempty"`
+	Command  *string             `json:"command,omitempty" yaml:"command,omitempty"`
+	Timeout  *int                `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// Excludes is a list of glob patterns, matched the same way as Paths, whose
+	// matches are subtracted from the files a FilePattern alias would otherwise scan.
+	Excludes []string `json:"excludes,omitempty" yaml:"excludes,omitempty"`
+	// ExcludeExtensions blacklists files by extension (e.g. ".min.js", ".map")
+	// from being read into a FilePattern alias's matched file set.
+	ExcludeExtensions []string `json:"excludeExtensions,omitempty" yaml:"excludeExtensions,omitempty"`
+	// MaxFileSize skips any matched file larger than this many bytes. A zero
+	// value means no size limit is enforced.
+	MaxFileSize int64 `json:"maxFileSize,omitempty" yaml:"maxFileSize,omitempty"`
+
+	// Workers bounds the number of files scanned concurrently for a
+	// FilePattern alias. A zero value defaults to runtime.NumCPU().
+	Workers int `json:"workers,omitempty" yaml:"workers,omitempty"`
+
+	// Languages restricts a Symbol alias to the given source languages (e.g.
+	// "go"). An empty list matches every language with a registered tokenizer.
+	Languages []string `json:"languages,omitempty" yaml:"languages,omitempty"`
+	// SimilarityThreshold is the minimum similarity score, in the range (0, 1],
+	// a candidate identifier's value must reach to be reported as a Symbol
+	// alias. Defaults to 1 (exact match) when unset.
+	SimilarityThreshold float64 `json:"similarityThreshold,omitempty" yaml:"similarityThreshold,omitempty"`
+
+	// RespectGitignore excludes paths matched by Paths that are ignored by a
+	// .gitignore found under the scanned directory.
+	RespectGitignore bool `json:"respectGitignore,omitempty" yaml:"respectGitignore,omitempty"`
+}
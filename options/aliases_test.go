@@ -0,0 +1,29 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAliasTypeCanonical_Idempotent guards against reintroducing a mixed-case
+// AliasType constant: Alias.Type is matched against these constants via
+// Canonical(), so a constant whose own value isn't already canonical could
+// never be dispatched to by a sanely-cased config.
+func TestAliasTypeCanonical_Idempotent(t *testing.T) {
+	types := []AliasType{
+		Literal,
+		CamelCase,
+		PascalCase,
+		SnakeCase,
+		UpperSnakeCase,
+		KebabCase,
+		DotCase,
+		FilePattern,
+		Command,
+		Symbol,
+	}
+	for _, aliasType := range types {
+		assert.Equal(t, aliasType, aliasType.Canonical(), "AliasType %q is not equal to its own Canonical() form", aliasType)
+	}
+}